@@ -0,0 +1,91 @@
+package wav
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Block is one chunk of decoded audio handed to a Reader.Blocks consumer.
+type Block struct {
+	// Samples holds up to the requested blockSize frames, normalized the
+	// same way as ReadSamplesF32. The last Block of a stream may be
+	// shorter than blockSize.
+	Samples []FloatSample
+	// StartSample is the index of Samples[0] within the stream.
+	StartSample uint64
+}
+
+// Blocks streams the file's audio as a channel of fixed-size Blocks,
+// decoding blockSize frames at a time on a background goroutine. Both
+// channels are closed once decoding finishes: normally at end of stream, or
+// early if a read/decode error occurs, in which case it's sent on the error
+// channel first. The block channel is unbuffered, so the decode goroutine
+// blocks until each Block is consumed, giving natural backpressure.
+func (r *Reader) Blocks(blockSize int) (<-chan Block, <-chan error) {
+	return r.ReadBlocksContext(context.Background(), blockSize)
+}
+
+// ReadBlocksContext is Blocks, but decoding also stops and both channels are
+// closed as soon as ctx is done.
+func (r *Reader) ReadBlocksContext(ctx context.Context, blockSize int) (<-chan Block, <-chan error) {
+	blocks := make(chan Block)
+	errs := make(chan error, 1)
+
+	if blockSize <= 0 {
+		errs <- errors.New("blockSize must be greater than 0")
+		close(blocks)
+		close(errs)
+		return blocks, errs
+	}
+
+	bufPool := sync.Pool{
+		New: func() interface{} {
+			return make([]FloatSample, blockSize)
+		},
+	}
+
+	go func() {
+		defer close(blocks)
+		defer close(errs)
+
+		var startSample uint64
+
+		for {
+			buf := bufPool.Get().([]FloatSample)
+
+			n, err := r.readSamplesF32(buf)
+			if n > 0 {
+				// buf is returned to the pool and may be reused by the
+				// next iteration, so the consumer needs its own copy.
+				block := Block{Samples: make([]FloatSample, n), StartSample: startSample}
+				copy(block.Samples, buf[:n])
+
+				select {
+				case blocks <- block:
+				case <-ctx.Done():
+					bufPool.Put(buf)
+					return
+				}
+
+				startSample += uint64(n)
+			}
+
+			bufPool.Put(buf)
+
+			if err != nil {
+				if err != io.EOF {
+					errs <- err
+				}
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return blocks, errs
+}
@@ -0,0 +1,90 @@
+package wav
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocks(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    1000,
+		BitsPerSample: 16,
+	}
+
+	samples := make([]Sample, 10)
+	for i := range samples {
+		samples[i].Values[0] = i
+	}
+
+	r := writeThenRead(t, format, samples)
+
+	blocks, errs := r.Blocks(4)
+
+	var got []float32
+	var starts []uint64
+	for block := range blocks {
+		starts = append(starts, block.StartSample)
+		for _, s := range block.Samples {
+			got = append(got, s.Values[0])
+		}
+	}
+	require.NoError(t, <-errs)
+
+	require.Len(t, got, 10)
+	for i, v := range got {
+		assert.InDelta(t, float32(i)/32768, v, 1e-6)
+	}
+	assert.Equal(t, []uint64{0, 4, 8}, starts)
+}
+
+func TestBlocksInvalidBlockSize(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    1000,
+		BitsPerSample: 16,
+	}
+
+	r := writeThenRead(t, format, make([]Sample, 4))
+
+	blocks, errs := r.Blocks(0)
+	for range blocks {
+		t.Fatal("no blocks should be sent for an invalid blockSize")
+	}
+	require.Error(t, <-errs)
+}
+
+func TestReadBlocksContextCancel(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    1000,
+		BitsPerSample: 16,
+	}
+
+	samples := make([]Sample, 100)
+	r := writeThenRead(t, format, samples)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks, errs := r.ReadBlocksContext(ctx, 4)
+
+	<-blocks
+	cancel()
+
+	for range blocks {
+		// drain until the decode goroutine notices cancellation and closes it
+	}
+
+	select {
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("error channel was never closed after cancellation")
+	}
+}
@@ -0,0 +1,501 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ListInfo holds the common RIFF "LIST"/"INFO" fields used to tag a WAV
+// file with descriptive metadata.
+type ListInfo struct {
+	Title    string // INAM
+	Artist   string // IART
+	Comment  string // ICMT
+	Date     string // ICRD
+	Genre    string // IGNR
+	Product  string // IPRD
+	Software string // ISFT
+}
+
+// LISTInfo reads and parses the file's "LIST"/"INFO" chunk, if present. The
+// result is cached, so it's safe to call more than once.
+func (r *Reader) LISTInfo() (*ListInfo, error) {
+	if r.listInfo != nil {
+		return r.listInfo, nil
+	}
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
+
+	listChunk := findChunk(riffChunk, "LIST")
+	if listChunk == nil {
+		return nil, errors.New("LIST chunk is not found")
+	}
+
+	data, err := io.ReadAll(listChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 4 || string(data[0:4]) != "INFO" {
+		return nil, errors.New("LIST chunk does not contain an INFO list")
+	}
+
+	info := &ListInfo{}
+	offset := 4
+
+	for offset+8 <= len(data) {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		offset += 8
+
+		if offset+size > len(data) {
+			break
+		}
+		value := strings.TrimRight(string(data[offset:offset+size]), "\x00")
+
+		switch id {
+		case "INAM":
+			info.Title = value
+		case "IART":
+			info.Artist = value
+		case "ICMT":
+			info.Comment = value
+		case "ICRD":
+			info.Date = value
+		case "IGNR":
+			info.Genre = value
+		case "IPRD":
+			info.Product = value
+		case "ISFT":
+			info.Software = value
+		}
+
+		offset += size
+		if size%2 == 1 {
+			offset++
+		}
+	}
+
+	r.listInfo = info
+
+	return info, nil
+}
+
+// bextCore is the fixed-size, 602-byte prefix of a Broadcast-WAV `bext`
+// chunk. The loudness fields introduced by BWF v1/v2 and the remaining
+// reserved bytes aren't surfaced, so they're skipped as a single block.
+type bextCore struct {
+	Description         [256]byte
+	Originator          [32]byte
+	OriginatorReference [32]byte
+	OriginationDate     [10]byte
+	OriginationTime     [8]byte
+	TimeReferenceLow    uint32
+	TimeReferenceHigh   uint32
+	Version             uint16
+	UMID                [64]byte
+	Reserved            [190]byte
+}
+
+// BextInfo holds the Broadcast-WAV `bext` fields this package surfaces.
+// Anything written after these fixed fields in the chunk is free-form
+// coding history text.
+type BextInfo struct {
+	Description         string
+	Originator          string
+	OriginatorReference string
+	OriginationDate     string
+	OriginationTime     string
+	TimeReference       uint64
+	UMID                [64]byte
+	CodingHistory       string
+}
+
+// Bext reads and parses the file's Broadcast-WAV `bext` chunk, if present.
+// The result is cached, so it's safe to call more than once.
+func (r *Reader) Bext() (*BextInfo, error) {
+	if r.bextInfo != nil {
+		return r.bextInfo, nil
+	}
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
+
+	bextChunk := findChunk(riffChunk, "bext")
+	if bextChunk == nil {
+		return nil, errors.New("bext chunk is not found")
+	}
+
+	var core bextCore
+	if err := binary.Read(bextChunk, binary.LittleEndian, &core); err != nil {
+		return nil, err
+	}
+
+	codingHistory, err := io.ReadAll(bextChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BextInfo{
+		Description:         cString(core.Description[:]),
+		Originator:          cString(core.Originator[:]),
+		OriginatorReference: cString(core.OriginatorReference[:]),
+		OriginationDate:     cString(core.OriginationDate[:]),
+		OriginationTime:     cString(core.OriginationTime[:]),
+		TimeReference:       uint64(core.TimeReferenceHigh)<<32 | uint64(core.TimeReferenceLow),
+		UMID:                core.UMID,
+		CodingHistory:       cString(codingHistory),
+	}
+
+	r.bextInfo = info
+
+	return info, nil
+}
+
+// CuePoint is a single marker from a WAV `cue ` chunk.
+type CuePoint struct {
+	ID           uint32
+	Position     uint32
+	DataChunkID  string
+	ChunkStart   uint32
+	BlockStart   uint32
+	SampleOffset uint32
+}
+
+type cuePointRaw struct {
+	ID           uint32
+	Position     uint32
+	DataChunkID  [4]byte
+	ChunkStart   uint32
+	BlockStart   uint32
+	SampleOffset uint32
+}
+
+// CuePoints reads and parses the file's `cue ` chunk, if present. The
+// result is cached, so it's safe to call more than once.
+func (r *Reader) CuePoints() ([]CuePoint, error) {
+	if r.cuePointsRead {
+		return r.cuePoints, nil
+	}
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
+
+	cueChunk := findChunk(riffChunk, "cue ")
+	if cueChunk == nil {
+		return nil, errors.New("cue chunk is not found")
+	}
+
+	data, err := io.ReadAll(cueChunk)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, errors.New("cue chunk is too small to contain its point count")
+	}
+
+	// The declared count is attacker-controlled and not trustworthy on its
+	// own (it need not match ChunkSize, which is itself just a header
+	// field), so it's checked against how much data was really read, and a
+	// count that data can't hold is rejected with an error.
+	numCuePoints := binary.LittleEndian.Uint32(data[0:4])
+
+	const cuePointSize = 24 // 6 uint32 fields per riff.org's cue point layout
+	if maxPoints := uint32(len(data)-4) / cuePointSize; numCuePoints > maxPoints {
+		return nil, errors.New("cue chunk's point count exceeds the data actually present")
+	}
+
+	points := make([]CuePoint, numCuePoints)
+	body := bytes.NewReader(data[4:])
+	for i := range points {
+		var raw cuePointRaw
+		if err := binary.Read(body, binary.LittleEndian, &raw); err != nil {
+			return nil, err
+		}
+
+		points[i] = CuePoint{
+			ID:           raw.ID,
+			Position:     raw.Position,
+			DataChunkID:  string(raw.DataChunkID[:]),
+			ChunkStart:   raw.ChunkStart,
+			BlockStart:   raw.BlockStart,
+			SampleOffset: raw.SampleOffset,
+		}
+	}
+
+	r.cuePoints = points
+	r.cuePointsRead = true
+
+	return points, nil
+}
+
+// SampleLoop is a single MIDI-style loop point from a WAV `smpl` chunk.
+type SampleLoop struct {
+	CuePointID uint32
+	Type       uint32
+	Start      uint32
+	End        uint32
+	Fraction   uint32
+	PlayCount  uint32
+}
+
+// SmplInfo holds a WAV `smpl` chunk's sampler fields and loop points.
+type SmplInfo struct {
+	Manufacturer      uint32
+	Product           uint32
+	SamplePeriod      uint32
+	MIDIUnityNote     uint32
+	MIDIPitchFraction uint32
+	SMPTEFormat       uint32
+	SMPTEOffset       uint32
+	Loops             []SampleLoop
+}
+
+type smplHeader struct {
+	Manufacturer      uint32
+	Product           uint32
+	SamplePeriod      uint32
+	MIDIUnityNote     uint32
+	MIDIPitchFraction uint32
+	SMPTEFormat       uint32
+	SMPTEOffset       uint32
+	NumSampleLoops    uint32
+	SamplerDataSize   uint32
+}
+
+// Smpl reads and parses the file's `smpl` chunk, if present. The result is
+// cached, so it's safe to call more than once.
+func (r *Reader) Smpl() (*SmplInfo, error) {
+	if r.smplInfo != nil {
+		return r.smplInfo, nil
+	}
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
+
+	smplChunk := findChunk(riffChunk, "smpl")
+	if smplChunk == nil {
+		return nil, errors.New("smpl chunk is not found")
+	}
+
+	data, err := io.ReadAll(smplChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		smplHeaderSize = 36 // 9 uint32 fields
+		sampleLoopSize = 24 // 6 uint32 fields
+	)
+	if len(data) < smplHeaderSize {
+		return nil, errors.New("smpl chunk is too small to contain its header")
+	}
+
+	var header smplHeader
+	if err := binary.Read(bytes.NewReader(data[:smplHeaderSize]), binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+
+	// The declared loop count is attacker-controlled and not trustworthy on
+	// its own, so it's checked against how much data was really read rather
+	// than against ChunkSize, which is itself just a header field.
+	if maxLoops := uint32(len(data)-smplHeaderSize) / sampleLoopSize; header.NumSampleLoops > maxLoops {
+		return nil, errors.New("smpl chunk's loop count exceeds the data actually present")
+	}
+
+	loops := make([]SampleLoop, header.NumSampleLoops)
+	body := bytes.NewReader(data[smplHeaderSize:])
+	for i := range loops {
+		if err := binary.Read(body, binary.LittleEndian, &loops[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	info := &SmplInfo{
+		Manufacturer:      header.Manufacturer,
+		Product:           header.Product,
+		SamplePeriod:      header.SamplePeriod,
+		MIDIUnityNote:     header.MIDIUnityNote,
+		MIDIPitchFraction: header.MIDIPitchFraction,
+		SMPTEFormat:       header.SMPTEFormat,
+		SMPTEOffset:       header.SMPTEOffset,
+		Loops:             loops,
+	}
+
+	r.smplInfo = info
+
+	return info, nil
+}
+
+// ID3 returns the raw bytes of the file's `id3 ` chunk, if present. Parsing
+// the ID3v2 tag itself is left to a dedicated ID3 library. The result is
+// cached, so it's safe to call more than once.
+func (r *Reader) ID3() ([]byte, error) {
+	if r.id3Read {
+		return r.id3, nil
+	}
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
+
+	id3Chunk := findChunk(riffChunk, "id3 ")
+	if id3Chunk == nil {
+		id3Chunk = findChunk(riffChunk, "ID3 ")
+	}
+	if id3Chunk == nil {
+		return nil, errors.New("id3 chunk is not found")
+	}
+
+	data, err := io.ReadAll(id3Chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	data = trimID3Padding(data)
+
+	r.id3 = data
+	r.id3Read = true
+
+	return data, nil
+}
+
+// trimID3Padding drops the single trailing zero byte that findChunk's
+// underlying RIFF chunk reader adds to pad an odd-length chunk to an even
+// size, using the ID3v2 header's own synchsafe size field to recover the
+// tag's real length. Data that isn't a recognizable ID3v2 tag is returned
+// unmodified.
+func trimID3Padding(data []byte) []byte {
+	const headerSize = 10
+	if len(data) < headerSize || string(data[0:3]) != "ID3" {
+		return data
+	}
+
+	size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+	tagLen := headerSize + size
+	if tagLen < len(data) {
+		return data[:tagLen]
+	}
+
+	return data
+}
+
+// cString trims trailing NUL padding from a fixed-width RIFF metadata field.
+func cString(b []byte) string {
+	return strings.TrimRight(string(b), "\x00")
+}
+
+// encodeListInfo builds the payload of a "LIST"/"INFO" chunk from the
+// non-empty fields of info.
+func encodeListInfo(info ListInfo) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("INFO")
+
+	fields := []struct {
+		id    string
+		value string
+	}{
+		{"INAM", info.Title},
+		{"IART", info.Artist},
+		{"ICMT", info.Comment},
+		{"ICRD", info.Date},
+		{"IGNR", info.Genre},
+		{"IPRD", info.Product},
+		{"ISFT", info.Software},
+	}
+
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+
+		data := []byte(f.value)
+		buf.WriteString(f.id)
+		binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+		buf.Write(data)
+		if len(data)%2 == 1 {
+			buf.WriteByte(0)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// encodeBext builds the payload of a Broadcast-WAV `bext` chunk from info.
+func encodeBext(info BextInfo) []byte {
+	var core bextCore
+	copy(core.Description[:], info.Description)
+	copy(core.Originator[:], info.Originator)
+	copy(core.OriginatorReference[:], info.OriginatorReference)
+	copy(core.OriginationDate[:], info.OriginationDate)
+	copy(core.OriginationTime[:], info.OriginationTime)
+	core.TimeReferenceLow = uint32(info.TimeReference)
+	core.TimeReferenceHigh = uint32(info.TimeReference >> 32)
+	core.UMID = info.UMID
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, core)
+	buf.WriteString(info.CodingHistory)
+
+	return buf.Bytes()
+}
+
+// encodeCuePoints builds the payload of a `cue ` chunk from points.
+func encodeCuePoints(points []CuePoint) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(len(points)))
+
+	for _, p := range points {
+		raw := cuePointRaw{
+			ID:           p.ID,
+			Position:     p.Position,
+			ChunkStart:   p.ChunkStart,
+			BlockStart:   p.BlockStart,
+			SampleOffset: p.SampleOffset,
+		}
+
+		dataChunkID := p.DataChunkID
+		if dataChunkID == "" {
+			dataChunkID = "data"
+		}
+		copy(raw.DataChunkID[:], dataChunkID)
+
+		binary.Write(&buf, binary.LittleEndian, raw)
+	}
+
+	return buf.Bytes()
+}
+
+// encodeSmpl builds the payload of a `smpl` chunk from info.
+func encodeSmpl(info SmplInfo) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, smplHeader{
+		Manufacturer:      info.Manufacturer,
+		Product:           info.Product,
+		SamplePeriod:      info.SamplePeriod,
+		MIDIUnityNote:     info.MIDIUnityNote,
+		MIDIPitchFraction: info.MIDIPitchFraction,
+		SMPTEFormat:       info.SMPTEFormat,
+		SMPTEOffset:       info.SMPTEOffset,
+		NumSampleLoops:    uint32(len(info.Loops)),
+	})
+
+	for _, loop := range info.Loops {
+		binary.Write(&buf, binary.LittleEndian, loop)
+	}
+
+	return buf.Bytes()
+}
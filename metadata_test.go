@@ -0,0 +1,234 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLISTInfoRoundTrip(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "wav-test-*.wav")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	w, err := NewWriter(f, format)
+	require.NoError(t, err)
+	_, err = w.WriteSamples([]Sample{{Values: [MaxChannels]int{1}}})
+	require.NoError(t, err)
+
+	want := ListInfo{Title: "Test Tone", Artist: "go-wav", Comment: "generated by a test"}
+	require.NoError(t, w.WriteLISTInfo(want))
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+
+	r := NewReader(bytes.NewReader(data))
+	got, err := r.LISTInfo()
+	require.NoError(t, err)
+	assert.Equal(t, want.Title, got.Title)
+	assert.Equal(t, want.Artist, got.Artist)
+	assert.Equal(t, want.Comment, got.Comment)
+
+	samples, err := r.ReadSamples(1)
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 1, samples[0].Values[0])
+
+	// Calling the accessor again must return the same cached result instead
+	// of re-reading the chunk's already-consumed section reader.
+	again, err := r.LISTInfo()
+	require.NoError(t, err)
+	assert.Equal(t, got, again)
+}
+
+// appendMaliciousChunk returns data with a chunk of the given id appended,
+// whose declared ChunkSize lies far beyond the actual payload bytes
+// provided, and patches the RIFF file size so go-riff will look at it. This
+// simulates a hostile file claiming a chunk is much larger than it is.
+func appendMaliciousChunk(t *testing.T, data []byte, id string, declaredSize uint32, payload []byte) []byte {
+	t.Helper()
+	require.Len(t, id, 4)
+
+	out := append([]byte{}, data...)
+	out = append(out, []byte(id)...)
+	sizeBuf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sizeBuf, declaredSize)
+	out = append(out, sizeBuf...)
+	out = append(out, payload...)
+
+	fileSize := uint32(len(out) - 8)
+	binary.LittleEndian.PutUint32(out[4:8], fileSize)
+
+	return out
+}
+
+func TestCuePointsRejectsCountExceedingActualData(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	}
+
+	base := writeThenReadBytes(t, format, []Sample{{Values: [MaxChannels]int{1}}})
+
+	// Declares an enormous ChunkSize and point count, but only 4 bytes of
+	// actual payload (the count field itself) follow.
+	payload := make([]byte, 4)
+	binary.LittleEndian.PutUint32(payload, 100_000_000)
+	malicious := appendMaliciousChunk(t, base, "cue ", 0xF0000000, payload)
+
+	r := NewReader(bytes.NewReader(malicious))
+	_, err := r.CuePoints()
+	require.Error(t, err)
+}
+
+func TestSmplRejectsLoopCountExceedingActualData(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	}
+
+	base := writeThenReadBytes(t, format, []Sample{{Values: [MaxChannels]int{1}}})
+
+	header := make([]byte, 36)
+	binary.LittleEndian.PutUint32(header[28:32], 100_000_000) // NumSampleLoops
+	malicious := appendMaliciousChunk(t, base, "smpl", 0xF0000000, header)
+
+	r := NewReader(bytes.NewReader(malicious))
+	_, err := r.Smpl()
+	require.Error(t, err)
+}
+
+func TestBextAndID3RoundTrip(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "wav-test-*.wav")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	w, err := NewWriter(f, format)
+	require.NoError(t, err)
+	_, err = w.WriteSamples([]Sample{{Values: [MaxChannels]int{1}}})
+	require.NoError(t, err)
+
+	wantBext := BextInfo{Description: "Test Tone", Originator: "go-wav", CodingHistory: "A=PCM,F=8000,W=16"}
+	require.NoError(t, w.WriteBext(wantBext))
+
+	// An odd-length ID3v2 tag payload, to exercise the even-padding the
+	// underlying RIFF chunk reader adds.
+	wantID3 := []byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, 5, 'T', 'I', 'T', '2', 0}
+	require.Len(t, wantID3, 15)
+	require.NoError(t, w.WriteID3(wantID3))
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+
+	r := NewReader(bytes.NewReader(data))
+
+	gotBext, err := r.Bext()
+	require.NoError(t, err)
+	assert.Equal(t, wantBext.Description, gotBext.Description)
+	assert.Equal(t, wantBext.Originator, gotBext.Originator)
+	assert.Equal(t, wantBext.CodingHistory, gotBext.CodingHistory)
+
+	gotID3, err := r.ID3()
+	require.NoError(t, err)
+	assert.Equal(t, wantID3, gotID3)
+
+	// Calling the accessor again must return the same cached result instead
+	// of re-reading the chunk's already-consumed section reader.
+	again, err := r.ID3()
+	require.NoError(t, err)
+	assert.Equal(t, gotID3, again)
+}
+
+func TestCuePointsAndSmplRoundTrip(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "wav-test-*.wav")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	w, err := NewWriter(f, format)
+	require.NoError(t, err)
+	_, err = w.WriteSamples([]Sample{{Values: [MaxChannels]int{1}}, {Values: [MaxChannels]int{2}}})
+	require.NoError(t, err)
+
+	wantCues := []CuePoint{
+		{ID: 1, Position: 0, DataChunkID: "data", SampleOffset: 0},
+		{ID: 2, Position: 1, DataChunkID: "data", SampleOffset: 1},
+	}
+	require.NoError(t, w.WriteCuePoints(wantCues))
+
+	wantSmpl := SmplInfo{
+		MIDIUnityNote: 60,
+		Loops: []SampleLoop{
+			{CuePointID: 1, Start: 0, End: 1, PlayCount: 0},
+		},
+	}
+	require.NoError(t, w.WriteSmpl(wantSmpl))
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+
+	r := NewReader(bytes.NewReader(data))
+
+	gotCues, err := r.CuePoints()
+	require.NoError(t, err)
+	require.Len(t, gotCues, 2)
+	assert.Equal(t, wantCues[0].ID, gotCues[0].ID)
+	assert.Equal(t, wantCues[1].SampleOffset, gotCues[1].SampleOffset)
+	assert.Equal(t, "data", gotCues[0].DataChunkID)
+
+	gotSmpl, err := r.Smpl()
+	require.NoError(t, err)
+	assert.Equal(t, wantSmpl.MIDIUnityNote, gotSmpl.MIDIUnityNote)
+	require.Len(t, gotSmpl.Loops, 1)
+	assert.Equal(t, uint32(1), gotSmpl.Loops[0].CuePointID)
+
+	chunks, err := r.Chunks()
+	require.NoError(t, err)
+	ids := make([]string, len(chunks))
+	for i, c := range chunks {
+		ids[i] = c.ID
+	}
+	assert.Contains(t, ids, "cue ")
+	assert.Contains(t, ids, "smpl")
+
+	// Calling the accessors again must return the same cached results
+	// instead of re-reading their already-consumed section readers.
+	againCues, err := r.CuePoints()
+	require.NoError(t, err)
+	assert.Equal(t, gotCues, againCues)
+
+	againSmpl, err := r.Smpl()
+	require.NoError(t, err)
+	assert.Equal(t, gotSmpl, againSmpl)
+}
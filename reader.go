@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"math"
+	"strconv"
 	"time"
 
 	"github.com/youpy/go-riff"
@@ -16,6 +17,18 @@ type Reader struct {
 	r         *riff.Reader
 	riffChunk *riff.RIFFChunk
 	format    *WavFormat
+
+	// Cached results of the metadata accessors in metadata.go. Each chunk's
+	// underlying section reader is consumed on first read, so the parsed
+	// result is cached the same way format is, rather than re-read.
+	listInfo      *ListInfo
+	bextInfo      *BextInfo
+	cuePoints     []CuePoint
+	cuePointsRead bool
+	smplInfo      *SmplInfo
+	id3           []byte
+	id3Read       bool
+
 	*WavData // Embed WavData to access its fields
 }
 
@@ -82,6 +95,119 @@ func (r *Reader) GetCurrentPosition() (uint32, error) {
 	return r.WavData.Position, nil
 }
 
+// Seek implements io.Seeker against the `data` chunk: offset 0 is the first
+// sample frame and Size is the byte immediately past the last one. This
+// relies on the original RIFF source's io.ReaderAt (required by NewReader)
+// to reposition; an error is returned in the (currently unreachable, but
+// checked defensively) case where that's not possible. The resulting
+// position is clamped to the data chunk bounds and snapped down to a
+// BlockAlign boundary, so seeks always land on a whole sample frame.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	if err := r.loadWavData(); err != nil {
+		return 0, err
+	}
+
+	if r.WavData.seeker == nil {
+		return 0, errors.New("underlying reader does not support seeking")
+	}
+
+	format, err := r.Format()
+	if err != nil {
+		return 0, err
+	}
+
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = int64(r.WavData.Position) + offset
+	case io.SeekEnd:
+		target = int64(r.WavData.Size) + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if target < 0 {
+		target = 0
+	}
+	if target > int64(r.WavData.Size) {
+		target = int64(r.WavData.Size)
+	}
+	if format.BlockAlign > 0 {
+		target -= target % int64(format.BlockAlign)
+	}
+
+	if _, err := r.WavData.seeker.Seek(target, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	// The bufio.Reader may hold buffered bytes from the old position, so
+	// it has to be rebuilt on top of the now-repositioned seeker.
+	r.WavData.internalReader = bufio.NewReader(r.WavData.seeker)
+	r.WavData.Position = uint32(target)
+
+	return target, nil
+}
+
+// SeekToSample seeks to the start of sample frame n.
+func (r *Reader) SeekToSample(n uint64) error {
+	format, err := r.Format()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Seek(int64(n)*int64(format.BlockAlign), io.SeekStart)
+	return err
+}
+
+// SeekToDuration seeks to the sample frame closest to d.
+func (r *Reader) SeekToDuration(d time.Duration) error {
+	format, err := r.Format()
+	if err != nil {
+		return err
+	}
+
+	return r.SeekToSample(uint64(math.Round(d.Seconds() * float64(format.SampleRate))))
+}
+
+// SamplePosition returns the current position as a sample frame index.
+func (r *Reader) SamplePosition() (uint64, error) {
+	if err := r.loadWavData(); err != nil {
+		return 0, err
+	}
+
+	format, err := r.Format()
+	if err != nil {
+		return 0, err
+	}
+	if format.BlockAlign == 0 {
+		return 0, nil
+	}
+
+	return uint64(r.WavData.Position) / uint64(format.BlockAlign), nil
+}
+
+// TimePosition returns the current position as a time.Duration from the
+// start of the data chunk.
+func (r *Reader) TimePosition() (time.Duration, error) {
+	format, err := r.Format()
+	if err != nil {
+		return 0, err
+	}
+	if format.SampleRate == 0 {
+		return 0, nil
+	}
+
+	samplePosition, err := r.SamplePosition()
+	if err != nil {
+		return 0, err
+	}
+
+	sec := float64(samplePosition) / float64(format.SampleRate)
+	return time.Duration(sec * float64(time.Second)), nil
+}
+
 // ReadSamples reads a specified number of samples (or a default if not specified)
 // from the WAV data stream. It handles different audio formats.
 func (r *Reader) ReadSamples(params ...uint32) (samples []Sample, err error) {
@@ -169,6 +295,104 @@ func (r *Reader) ReadSamples(params ...uint32) (samples []Sample, err error) {
 	return
 }
 
+// ReadSamplesF32 reads a specified number of samples (or a default if not
+// specified) from the WAV data stream, normalizing every supported
+// AudioFormat directly to float32 values in the range [-1.0, 1.0], without
+// going through the lossy int path used by ReadSamples.
+func (r *Reader) ReadSamplesF32(params ...uint32) (samples []FloatSample, err error) {
+	numSamples := 2048
+	if len(params) > 0 {
+		numSamples = int(params[0])
+	}
+
+	samples = make([]FloatSample, numSamples)
+	n, err := r.readSamplesF32(samples)
+	if n == 0 && err == io.EOF {
+		return nil, io.EOF
+	}
+
+	return samples, err
+}
+
+// readSamplesF32 decodes into buf, up to len(buf) frames, and returns the
+// number of frames actually decoded. It underlies both ReadSamplesF32,
+// which allocates buf itself, and Blocks, which reuses a pooled one. On a
+// short read at end of stream it returns the partial count along with
+// io.EOF; buf[n:] is not cleared, so apart from any channels of buf[n]
+// decoded before the data ran out it keeps whatever it held before (zero
+// values only for a freshly allocated buf).
+func (r *Reader) readSamplesF32(buf []FloatSample) (n int, err error) {
+	format, err := r.Format()
+	if err != nil {
+		return 0, err
+	}
+
+	numChannels := int(format.NumChannels)
+	blockAlign := int(format.BlockAlign)
+	bitsPerSample := int(format.BitsPerSample)
+	numSamples := len(buf)
+
+	bytes := make([]byte, numSamples*blockAlign)
+	read, err := r.Read(bytes) // This calls r.Read, which then calls r.WavData.Read (embedded io.Reader)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if read == 0 && err == io.EOF {
+		return 0, io.EOF
+	}
+
+	offset := 0
+
+	for i := 0; i < numSamples; i++ {
+		for j := 0; j < numChannels; j++ {
+			soffset := offset + (j * bitsPerSample / 8)
+
+			switch format.AudioFormat {
+			case AudioFormatIEEEFloat:
+				if soffset+3 >= read { // Check bounds against read, actual bytes read
+					return i, io.EOF
+				}
+				bits :=
+					uint32((int(bytes[soffset+3]) << 24) +
+						(int(bytes[soffset+2]) << 16) +
+						(int(bytes[soffset+1]) << 8) +
+						int(bytes[soffset]))
+				buf[i].Values[j] = math.Float32frombits(bits)
+
+			case AudioFormatALaw:
+				if soffset >= read { // Check bounds against read
+					return i, io.EOF
+				}
+				pcm := g711.DecodeAlawFrame(bytes[soffset])
+				buf[i].Values[j] = float32(pcm) / math.MaxInt16
+
+			case AudioFormatMULaw:
+				if soffset >= read { // Check bounds against read
+					return i, io.EOF
+				}
+				pcm := g711.DecodeUlawFrame(bytes[soffset])
+				buf[i].Values[j] = float32(pcm) / math.MaxInt16
+
+			default:
+				var val uint
+				bytesForSample := bitsPerSample / 8
+				if soffset+bytesForSample > read { // Check bounds against read
+					return i, io.EOF
+				}
+				for b_idx := 0; b_idx < bytesForSample; b_idx++ {
+					val += uint(bytes[soffset+b_idx]) << uint(b_idx*8)
+				}
+				fullScale := float32(int64(1) << uint(bitsPerSample-1))
+				buf[i].Values[j] = float32(toInt(val, bitsPerSample)) / fullScale
+			}
+		}
+
+		offset += blockAlign
+	}
+
+	return numSamples, nil
+}
+
 func (r *Reader) IntValue(sample Sample, channel uint) int {
 	return sample.Values[channel]
 }
@@ -180,20 +404,59 @@ func (r *Reader) FloatValue(sample Sample, channel uint) float64 {
 	return float64(r.IntValue(sample, channel)) / math.Pow(2, float64(r.format.BitsPerSample-1))
 }
 
-func (r *Reader) readFormat() (fmt *WavFormat, err error) {
-	var riffChunk *riff.RIFFChunk
+// FloatValueF32 returns the already-normalized value for channel from a
+// FloatSample produced by ReadSamplesF32.
+func (r *Reader) FloatValueF32(sample FloatSample, channel uint) float32 {
+	return sample.Values[channel]
+}
 
-	fmt = new(WavFormat)
+// ChunkInfo describes a top-level RIFF sub-chunk without reading its
+// payload.
+type ChunkInfo struct {
+	ID   string
+	Size uint32
+}
 
-	if r.riffChunk == nil {
-		riffChunk, err = r.r.Read()
-		if err != nil {
-			return
-		}
+// Chunks returns metadata for every top-level sub-chunk in the RIFF
+// container, in file order, including `fmt ` and `data`. Use it to discover
+// chunks this package doesn't otherwise expose a typed accessor for.
+func (r *Reader) Chunks() ([]ChunkInfo, error) {
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return nil, err
+	}
 
-		r.riffChunk = riffChunk
-	} else {
-		riffChunk = r.riffChunk
+	chunks := make([]ChunkInfo, len(riffChunk.Chunks))
+	for i, ch := range riffChunk.Chunks {
+		chunks[i] = ChunkInfo{ID: string(ch.ChunkID[:]), Size: ch.ChunkSize}
+	}
+
+	return chunks, nil
+}
+
+// ensureRIFFChunk returns the parsed top-level RIFF chunk list, reading and
+// caching it on the reader the first time it's needed.
+func (r *Reader) ensureRIFFChunk() (riffChunk *riff.RIFFChunk, err error) {
+	if r.riffChunk != nil {
+		return r.riffChunk, nil
+	}
+
+	riffChunk, err = r.r.Read()
+	if err != nil {
+		return
+	}
+
+	r.riffChunk = riffChunk
+
+	return
+}
+
+func (r *Reader) readFormat() (fmt *WavFormat, err error) {
+	fmt = new(WavFormat)
+
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return
 	}
 
 	fmtChunk := findChunk(riffChunk, "fmt ")
@@ -203,18 +466,67 @@ func (r *Reader) readFormat() (fmt *WavFormat, err error) {
 		return
 	}
 
-	err = binary.Read(fmtChunk, binary.LittleEndian, fmt)
+	var core wavFormatCore
+	err = binary.Read(fmtChunk, binary.LittleEndian, &core)
 	if err != nil {
 		return
 	}
 
+	fmt.AudioFormat = core.AudioFormat
+	fmt.NumChannels = core.NumChannels
+	fmt.SampleRate = core.SampleRate
+	fmt.ByteRate = core.ByteRate
+	fmt.BlockAlign = core.BlockAlign
+	fmt.BitsPerSample = core.BitsPerSample
+
 	if fmt.BitsPerSample == 0 {
 		return nil, errors.New("BitsPerSample is 0, which is invalid for audio format")
 	}
 
+	// WAVE_FORMAT_EXTENSIBLE carries a 24-byte extension after the core
+	// fields: cbSize, ValidBitsPerSample, a channel mask, and a SubFormat
+	// GUID whose leading 16 bits are the real format tag. It's how
+	// >2-channel and most 24-bit PCM files declare their layout.
+	if fmt.AudioFormat == AudioFormatExtensible && fmtChunk.ChunkSize >= 40 {
+		var ext wavFormatExtension
+		if err = binary.Read(fmtChunk, binary.LittleEndian, &ext); err != nil {
+			return
+		}
+
+		fmt.ExtensionSize = ext.ExtensionSize
+		fmt.ValidBitsPerSample = ext.ValidBitsPerSample
+		fmt.ChannelMask = ext.ChannelMask
+		fmt.SubFormat = ext.SubFormat
+		fmt.AudioFormat = binary.LittleEndian.Uint16(ext.SubFormat[0:2])
+	}
+
+	if fmt.NumChannels > MaxChannels {
+		return nil, errors.New("NumChannels " + strconv.Itoa(int(fmt.NumChannels)) + " exceeds the supported maximum of " + strconv.Itoa(MaxChannels))
+	}
+
 	return
 }
 
+// wavFormatCore is the canonical 16-byte PCM `fmt ` subchunk layout, common
+// to every WavFormat regardless of whether it's extended.
+type wavFormatCore struct {
+	AudioFormat   uint16
+	NumChannels   uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+// wavFormatExtension is the extra payload present when a `fmt ` chunk uses
+// WAVE_FORMAT_EXTENSIBLE, appended after wavFormatCore.
+type wavFormatExtension struct {
+	ExtensionSize      uint16
+	ValidBitsPerSample uint16
+	ChannelMask        uint32
+	SubFormat          [16]byte
+}
+
 func (r *Reader) loadWavData() error {
 	if r.WavData == nil {
 		data, err := r.readData()
@@ -228,17 +540,9 @@ func (r *Reader) loadWavData() error {
 }
 
 func (r *Reader) readData() (data *WavData, err error) {
-	var riffChunk *riff.RIFFChunk
-
-	if r.riffChunk == nil {
-		riffChunk, err = r.r.Read()
-		if err != nil {
-			return
-		}
-
-		r.riffChunk = riffChunk
-	} else {
-		riffChunk = r.riffChunk
+	riffChunk, err := r.ensureRIFFChunk()
+	if err != nil {
+		return
 	}
 
 	dataChunk := findChunk(riffChunk, "data")
@@ -250,6 +554,13 @@ func (r *Reader) readData() (data *WavData, err error) {
 	// Initialize WavData with the internalReader set to bufio.NewReader(dataChunk)
 	data = &WavData{internalReader: bufio.NewReader(dataChunk), Size: dataChunk.ChunkSize, Position: 0}
 
+	// dataChunk.RIFFReader is an io.NewSectionReader under the hood
+	// whenever the original RIFF source was an io.ReaderAt, in which case
+	// it also implements io.Seeker. Expose that so Reader.Seek can use it.
+	if seeker, ok := dataChunk.RIFFReader.(io.ReadSeeker); ok {
+		data.seeker = seeker
+	}
+
 	return
 }
 
@@ -264,25 +575,16 @@ func findChunk(riffChunk *riff.RIFFChunk, id string) (chunk *riff.Chunk) {
 	return
 }
 
+// toInt sign-extends value, an unsigned reading of a bits-wide PCM sample,
+// into a native signed int. It works uniformly for any bit width (8, 16,
+// 24, 32, ...) rather than special-casing the common ones, so odd widths
+// like 24-bit PCM sign-extend exactly like 16- or 32-bit do.
 func toInt(value uint, bits int) int {
-	var result int
-
-	switch bits {
-	case 32:
-		result = int(int32(value))
-	case 16:
-		result = int(int16(value))
-	case 8:
-		result = int(int8(value))
-	default:
-		msb := uint(1 << (uint(bits) - 1))
+	msb := uint(1) << uint(bits-1)
 
-		if value >= msb {
-			result = -int((1 << uint(bits)) - value)
-		} else {
-			result = int(value)
-		}
+	if value >= msb {
+		return -int((uint(1) << uint(bits)) - value)
 	}
 
-	return result
+	return int(value)
 }
@@ -0,0 +1,296 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pcmSubFormatGUID is the SubFormat GUID used by WAVE_FORMAT_EXTENSIBLE
+// files carrying plain PCM (KSDATAFORMAT_SUBTYPE_PCM). Its first 4 bytes
+// encode the original wFormatTag (1, little-endian).
+var pcmSubFormatGUID = [16]byte{
+	0x01, 0x00, 0x00, 0x00,
+	0x00, 0x00,
+	0x00, 0x10,
+	0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71,
+}
+
+// writeThenRead round-trips samples through a Writer onto a seekable temp
+// file and returns a Reader over the resulting bytes.
+func writeThenRead(t *testing.T, format WavFormat, samples []Sample) *Reader {
+	t.Helper()
+
+	return NewReader(bytes.NewReader(writeThenReadBytes(t, format, samples)))
+}
+
+// writeThenReadBytes is writeThenRead, but returns the encoded file bytes
+// directly so a test can tamper with them before handing them to a Reader.
+func writeThenReadBytes(t *testing.T, format WavFormat, samples []Sample) []byte {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "wav-test-*.wav")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	w, err := NewWriter(f, format)
+	require.NoError(t, err)
+
+	_, err = w.WriteSamples(samples)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestWriterPadsOddLengthDataWithoutChunk(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 8,
+	}
+
+	// 3 samples of 8-bit mono audio is an odd-length `data` chunk, and
+	// Close is called directly without ever writing a trailing chunk.
+	data := writeThenReadBytes(t, format, []Sample{
+		{Values: [MaxChannels]int{1}},
+		{Values: [MaxChannels]int{2}},
+		{Values: [MaxChannels]int{3}},
+	})
+
+	assert.Equal(t, 0, len(data)%2, "RIFF files must have an even total length")
+
+	r := NewReader(bytes.NewReader(data))
+	samples, err := r.ReadSamples(3)
+	require.NoError(t, err)
+	require.Len(t, samples, 3)
+}
+
+func TestReadSamples24BitStereo(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   2,
+		SampleRate:    44100,
+		BitsPerSample: 24,
+	}
+
+	want := []Sample{
+		{Values: [MaxChannels]int{8388607, -8388608}},
+		{Values: [MaxChannels]int{1234, -5678}},
+	}
+
+	r := writeThenRead(t, format, want)
+
+	got, err := r.ReadSamples(uint32(len(want)))
+	require.NoError(t, err)
+	require.Len(t, got, len(want))
+
+	for i := range want {
+		assert.Equal(t, want[i].Values[0], got[i].Values[0])
+		assert.Equal(t, want[i].Values[1], got[i].Values[1])
+	}
+}
+
+func TestReadSamples32BitFloat5Point1(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatIEEEFloat,
+		NumChannels:   6,
+		SampleRate:    48000,
+		BitsPerSample: 32,
+	}
+
+	want := Sample{Values: [MaxChannels]int{
+		1073741824, -1073741824, 536870912, -536870912, 0, 2147483647,
+	}}
+
+	r := writeThenRead(t, format, []Sample{want})
+
+	got, err := r.ReadSamples(1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	for ch := 0; ch < int(format.NumChannels); ch++ {
+		assert.InDelta(t, want.Values[ch], got[0].Values[ch], 2)
+	}
+}
+
+func TestReadSamplesF32PCM16(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   2,
+		SampleRate:    44100,
+		BitsPerSample: 16,
+	}
+
+	want := []Sample{
+		{Values: [MaxChannels]int{32767, -32768}},
+		{Values: [MaxChannels]int{0, 16384}},
+	}
+
+	r := writeThenRead(t, format, want)
+
+	got, err := r.ReadSamplesF32(uint32(len(want)))
+	require.NoError(t, err)
+	require.Len(t, got, len(want))
+
+	for i := range want {
+		for ch := 0; ch < int(format.NumChannels); ch++ {
+			wantF := float32(want[i].Values[ch]) / 32768
+			assert.InDelta(t, wantF, got[i].Values[ch], 1e-4)
+			assert.InDelta(t, wantF, r.FloatValueF32(got[i], uint(ch)), 1e-4)
+		}
+	}
+}
+
+func TestALawMULawRoundTrip(t *testing.T) {
+	want := []Sample{
+		{Values: [MaxChannels]int{0}},
+		{Values: [MaxChannels]int{8000}},
+		{Values: [MaxChannels]int{-8000}},
+		{Values: [MaxChannels]int{32767}},
+		{Values: [MaxChannels]int{-32768}},
+	}
+
+	for _, audioFormat := range []uint16{AudioFormatALaw, AudioFormatMULaw} {
+		format := WavFormat{
+			AudioFormat:   audioFormat,
+			NumChannels:   1,
+			SampleRate:    8000,
+			BitsPerSample: 8,
+		}
+
+		r := writeThenRead(t, format, want)
+
+		gotInt, err := r.ReadSamples(uint32(len(want)))
+		require.NoError(t, err)
+		require.Len(t, gotInt, len(want))
+
+		r2 := writeThenRead(t, format, want)
+		gotFloat, err := r2.ReadSamplesF32(uint32(len(want)))
+		require.NoError(t, err)
+		require.Len(t, gotFloat, len(want))
+
+		// A-law/μ-law are lossy companding codecs, so round-tripped values
+		// only need to land in the same ballpark as the originals, and the
+		// int and float32 decode paths need to agree with each other.
+		for i := range want {
+			assert.InDelta(t, want[i].Values[0], gotInt[i].Values[0], 2000)
+			assert.InDelta(t, float32(gotInt[i].Values[0])/32768, gotFloat[i].Values[0], 1e-3)
+		}
+	}
+}
+
+func TestSeek(t *testing.T) {
+	format := WavFormat{
+		AudioFormat:   AudioFormatPCM,
+		NumChannels:   1,
+		SampleRate:    1000,
+		BitsPerSample: 16,
+	}
+
+	samples := make([]Sample, 10)
+	for i := range samples {
+		samples[i].Values[0] = i
+	}
+
+	r := writeThenRead(t, format, samples)
+
+	pos, err := r.Seek(int64(4*2), io.SeekStart) // frame 4, BlockAlign 2
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, pos)
+
+	samplePos, err := r.SamplePosition()
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, samplePos)
+
+	timePos, err := r.TimePosition()
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Millisecond, timePos)
+
+	got, err := r.ReadSamples(1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 4, got[0].Values[0])
+
+	require.NoError(t, r.SeekToSample(7))
+	got, err = r.ReadSamples(1)
+	require.NoError(t, err)
+	assert.Equal(t, 7, got[0].Values[0])
+
+	require.NoError(t, r.SeekToDuration(2*time.Millisecond))
+	got, err = r.ReadSamples(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got[0].Values[0])
+}
+
+func TestReadFormatExtensiblePCM(t *testing.T) {
+	var buf bytes.Buffer
+
+	const (
+		numChannels   = 2
+		sampleRate    = 44100
+		bitsPerSample = 16
+		blockAlign    = numChannels * bitsPerSample / 8
+	)
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // patched below
+	buf.WriteString("WAVE")
+
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(40))
+	binary.Write(&buf, binary.LittleEndian, wavFormatCore{
+		AudioFormat:   AudioFormatExtensible,
+		NumChannels:   numChannels,
+		SampleRate:    sampleRate,
+		ByteRate:      sampleRate * blockAlign,
+		BlockAlign:    blockAlign,
+		BitsPerSample: bitsPerSample,
+	})
+	binary.Write(&buf, binary.LittleEndian, wavFormatExtension{
+		ExtensionSize:      22,
+		ValidBitsPerSample: bitsPerSample,
+		ChannelMask:        0x3,
+		SubFormat:          pcmSubFormatGUID,
+	})
+
+	samples := []int16{1000, -1000, 2000, -2000}
+	pcm := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(s))
+	}
+
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+
+	fileBytes := buf.Bytes()
+	binary.LittleEndian.PutUint32(fileBytes[4:], uint32(len(fileBytes)-8))
+
+	r := NewReader(bytes.NewReader(fileBytes))
+
+	format, err := r.Format()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(AudioFormatPCM), format.AudioFormat)
+	assert.Equal(t, uint16(numChannels), format.NumChannels)
+	assert.Equal(t, uint16(bitsPerSample), format.ValidBitsPerSample)
+	assert.Equal(t, uint32(0x3), format.ChannelMask)
+
+	got, err := r.ReadSamples(2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, 1000, got[0].Values[0])
+	assert.Equal(t, -1000, got[0].Values[1])
+	assert.Equal(t, 2000, got[1].Values[0])
+	assert.Equal(t, -2000, got[1].Values[1])
+}
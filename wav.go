@@ -5,10 +5,11 @@ import (
 )
 
 const (
-	AudioFormatPCM       = 1
-	AudioFormatIEEEFloat = 3
-	AudioFormatALaw      = 6
-	AudioFormatMULaw     = 7
+	AudioFormatPCM        = 1
+	AudioFormatIEEEFloat  = 3
+	AudioFormatALaw       = 6
+	AudioFormatMULaw      = 7
+	AudioFormatExtensible = 0xFFFE
 )
 
 type WavFormat struct {
@@ -18,14 +19,30 @@ type WavFormat struct {
 	ByteRate      uint32
 	BlockAlign    uint16
 	BitsPerSample uint16
+
+	// The fields below are only populated when the `fmt ` chunk carries a
+	// WAVE_FORMAT_EXTENSIBLE payload, i.e. when its format tag is
+	// AudioFormatExtensible and it holds the full 40-byte extensible layout.
+	// In that case AudioFormat is resolved from
+	// SubFormat's leading format tag rather than left as
+	// AudioFormatExtensible, so callers can keep switching on AudioFormat
+	// as before.
+	ExtensionSize      uint16
+	ValidBitsPerSample uint16
+	ChannelMask        uint32
+	SubFormat          [16]byte
 }
 
 type WavData struct {
 	// Original io.Reader, which will be a *bufio.Reader.
 	// We keep this to allow oto to read from it.
-	internalReader io.Reader 
-	Size uint32
-	Position  uint32 // Exported to track read position
+	internalReader io.Reader
+	// seeker is the same data chunk as internalReader, but typed as
+	// io.ReadSeeker. It's non-nil only when the underlying RIFF source
+	// supports seeking, and is what Reader.Seek operates on.
+	seeker   io.ReadSeeker
+	Size     uint32
+	Position uint32 // Exported to track read position
 }
 
 // Read implements the io.Reader interface for WavData.
@@ -36,6 +53,16 @@ func (wd *WavData) Read(p []byte) (n int, err error) {
 	return n, err
 }
 
+// MaxChannels is the maximum number of channels a Sample or FloatSample can
+// hold. It covers everything up to 7.1 surround.
+const MaxChannels = 8
+
 type Sample struct {
-	Values [2]int
+	Values [MaxChannels]int
+}
+
+// FloatSample holds per-channel audio samples normalized to the range
+// [-1.0, 1.0], as produced by Reader.ReadSamplesF32.
+type FloatSample struct {
+	Values [MaxChannels]float32
 }
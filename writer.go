@@ -0,0 +1,268 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/zaf/g711"
+)
+
+// fmtChunkSize is the size in bytes of the canonical 16-byte PCM `fmt ` subchunk.
+const fmtChunkSize = 16
+
+// Writer streams RIFF/WAVE encoded audio to an io.Writer.
+//
+// The RIFF chunk size and the `data` chunk size are written as 0xFFFFFFFF
+// (length-unknown) up front and are only patched up on Close if the
+// underlying writer is also an io.WriteSeeker.
+type Writer struct {
+	w              io.Writer
+	format         WavFormat
+	dataSize       uint32
+	dataSizeOffset int64
+	seekable       bool
+	dataPadded     bool
+	trailingBytes  uint32
+}
+
+// NewWriter creates a Writer that encodes audio matching format and writes
+// the RIFF/WAVE header to w immediately.
+func NewWriter(w io.Writer, format WavFormat) (*Writer, error) {
+	if format.NumChannels == 0 {
+		return nil, errors.New("NumChannels must be greater than 0")
+	}
+	if format.NumChannels > MaxChannels {
+		return nil, errors.New("NumChannels " + strconv.Itoa(int(format.NumChannels)) + " exceeds the supported maximum of " + strconv.Itoa(MaxChannels))
+	}
+	if format.BitsPerSample == 0 {
+		return nil, errors.New("BitsPerSample must be greater than 0")
+	}
+
+	format.BlockAlign = format.NumChannels * (format.BitsPerSample / 8)
+	format.ByteRate = format.SampleRate * uint32(format.BlockAlign)
+
+	wr := &Writer{w: w, format: format}
+
+	if err := wr.writeHeader(); err != nil {
+		return nil, err
+	}
+
+	return wr, nil
+}
+
+func (wr *Writer) writeHeader() (err error) {
+	if _, err = wr.w.Write([]byte("RIFF")); err != nil {
+		return
+	}
+	if err = binary.Write(wr.w, binary.LittleEndian, uint32(0xFFFFFFFF)); err != nil {
+		return
+	}
+	if _, err = wr.w.Write([]byte("WAVE")); err != nil {
+		return
+	}
+
+	if _, err = wr.w.Write([]byte("fmt ")); err != nil {
+		return
+	}
+	if err = binary.Write(wr.w, binary.LittleEndian, uint32(fmtChunkSize)); err != nil {
+		return
+	}
+	if err = binary.Write(wr.w, binary.LittleEndian, wavFormatCore{
+		AudioFormat:   wr.format.AudioFormat,
+		NumChannels:   wr.format.NumChannels,
+		SampleRate:    wr.format.SampleRate,
+		ByteRate:      wr.format.ByteRate,
+		BlockAlign:    wr.format.BlockAlign,
+		BitsPerSample: wr.format.BitsPerSample,
+	}); err != nil {
+		return
+	}
+
+	if _, err = wr.w.Write([]byte("data")); err != nil {
+		return
+	}
+
+	if seeker, ok := wr.w.(io.WriteSeeker); ok {
+		if offset, serr := seeker.Seek(0, io.SeekCurrent); serr == nil {
+			wr.dataSizeOffset = offset
+			wr.seekable = true
+		}
+	}
+
+	err = binary.Write(wr.w, binary.LittleEndian, uint32(0xFFFFFFFF))
+
+	return
+}
+
+// Write writes raw, already-encoded bytes to the `data` chunk. It implements
+// io.Writer so a Writer can be used anywhere a byte sink is expected.
+func (wr *Writer) Write(p []byte) (n int, err error) {
+	n, err = wr.w.Write(p)
+	wr.dataSize += uint32(n)
+
+	return
+}
+
+// WriteSamples encodes samples according to the Writer's format and writes
+// them to the `data` chunk.
+func (wr *Writer) WriteSamples(samples []Sample) (n int, err error) {
+	numChannels := int(wr.format.NumChannels)
+	bitsPerSample := int(wr.format.BitsPerSample)
+	bytesPerSample := bitsPerSample / 8
+
+	buf := make([]byte, len(samples)*numChannels*bytesPerSample)
+	offset := 0
+
+	for _, sample := range samples {
+		for ch := 0; ch < numChannels; ch++ {
+			switch wr.format.AudioFormat {
+			case AudioFormatIEEEFloat:
+				bits := math.Float32bits(float32(sample.Values[ch]) / math.MaxInt32)
+				binary.LittleEndian.PutUint32(buf[offset:], bits)
+
+			case AudioFormatALaw:
+				buf[offset] = g711.EncodeAlawFrame(int16(sample.Values[ch]))
+
+			case AudioFormatMULaw:
+				buf[offset] = g711.EncodeUlawFrame(int16(sample.Values[ch]))
+
+			default:
+				putInt(buf[offset:offset+bytesPerSample], sample.Values[ch], bitsPerSample)
+			}
+
+			offset += bytesPerSample
+		}
+	}
+
+	return wr.Write(buf)
+}
+
+// padData writes the single zero byte RIFF requires to pad the `data` chunk
+// to an even length, if its size is odd and it hasn't already been padded.
+func (wr *Writer) padData() error {
+	if wr.dataPadded {
+		return nil
+	}
+	wr.dataPadded = true
+
+	if wr.dataSize%2 == 1 {
+		if _, err := wr.w.Write([]byte{0}); err != nil {
+			return err
+		}
+		wr.trailingBytes++
+	}
+
+	return nil
+}
+
+// WriteChunk appends an arbitrary, already-encoded sub-chunk (e.g. LIST,
+// bext, cue , smpl, id3 ) after the `data` chunk, padding it to an even
+// length as RIFF requires. It must be called after all WriteSamples/Write
+// calls and before Close, since it also pads out the `data` chunk itself if
+// its size is odd.
+func (wr *Writer) WriteChunk(id string, data []byte) error {
+	if len(id) != 4 {
+		return errors.New("chunk id must be 4 bytes")
+	}
+
+	if err := wr.padData(); err != nil {
+		return err
+	}
+
+	if _, err := wr.w.Write([]byte(id)); err != nil {
+		return err
+	}
+	if err := binary.Write(wr.w, binary.LittleEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	if _, err := wr.w.Write(data); err != nil {
+		return err
+	}
+
+	wr.trailingBytes += uint32(8 + len(data))
+	if len(data)%2 == 1 {
+		if _, err := wr.w.Write([]byte{0}); err != nil {
+			return err
+		}
+		wr.trailingBytes++
+	}
+
+	return nil
+}
+
+// WriteLISTInfo appends a "LIST"/"INFO" metadata chunk.
+func (wr *Writer) WriteLISTInfo(info ListInfo) error {
+	return wr.WriteChunk("LIST", encodeListInfo(info))
+}
+
+// WriteBext appends a Broadcast-WAV `bext` chunk.
+func (wr *Writer) WriteBext(info BextInfo) error {
+	return wr.WriteChunk("bext", encodeBext(info))
+}
+
+// WriteCuePoints appends a `cue ` chunk.
+func (wr *Writer) WriteCuePoints(points []CuePoint) error {
+	return wr.WriteChunk("cue ", encodeCuePoints(points))
+}
+
+// WriteSmpl appends a `smpl` chunk.
+func (wr *Writer) WriteSmpl(info SmplInfo) error {
+	return wr.WriteChunk("smpl", encodeSmpl(info))
+}
+
+// WriteID3 appends a raw `id3 ` chunk.
+func (wr *Writer) WriteID3(data []byte) error {
+	return wr.WriteChunk("id3 ", data)
+}
+
+// Close pads the `data` chunk to an even length if needed, then fixes up
+// the RIFF and `data` chunk sizes recorded during writing. If the
+// underlying writer isn't an io.WriteSeeker, the sizes are left as
+// 0xFFFFFFFF (length-unknown). If the underlying writer implements
+// io.Closer, Close also closes it.
+func (wr *Writer) Close() error {
+	if err := wr.padData(); err != nil {
+		return err
+	}
+
+	if seeker, ok := wr.w.(io.WriteSeeker); ok && wr.seekable {
+		if _, err := seeker.Seek(wr.dataSizeOffset, io.SeekStart); err != nil {
+			return err
+		}
+		if err := binary.Write(seeker, binary.LittleEndian, wr.dataSize); err != nil {
+			return err
+		}
+
+		riffSize := uint32(4+8+fmtChunkSize+8) + wr.dataSize + wr.trailingBytes
+		if _, err := seeker.Seek(4, io.SeekStart); err != nil {
+			return err
+		}
+		if err := binary.Write(seeker, binary.LittleEndian, riffSize); err != nil {
+			return err
+		}
+
+		if _, err := seeker.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	}
+
+	if closer, ok := wr.w.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// putInt writes the low bytesForSample bytes of value, little-endian,
+// truncating to bits-per-sample precision. It's the inverse of toInt.
+func putInt(buf []byte, value int, bits int) {
+	uval := uint32(int32(value))
+	bytesForSample := bits / 8
+
+	for i := 0; i < bytesForSample; i++ {
+		buf[i] = byte(uval >> uint(i*8))
+	}
+}